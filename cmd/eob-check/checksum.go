@@ -0,0 +1,49 @@
+package main
+
+import (
+	"log"
+	"os"
+
+	"github.com/franz-deleon/eob-check/internal/eob"
+	"github.com/franz-deleon/eob-check/internal/report"
+	"github.com/spf13/afero"
+)
+
+// runChecksum emits a fresh manifest for --dir, or, when --verify is given,
+// checks --dir's files against an existing one.
+func runChecksum(args []string) {
+	fs := newFlagSet("checksum")
+	targetDir := fs.String("dir", "storage", "The target dir to checksum")
+	verify := fs.String("verify", "", "Path to an existing manifest to verify --dir against, instead of emitting a new one")
+	currency := fs.String("currency", "USD", "ISO 4217 currency of the amounts encoded in filenames")
+	format := fs.String("format", "text", "Output format for --verify: text, json, csv or junit")
+	fs.Parse(args)
+
+	osFs := afero.NewOsFs()
+
+	if *verify == "" {
+		if err := eob.WriteChecksumManifest(osFs, *targetDir, os.Stdout); err != nil {
+			log.Fatalln(err)
+		}
+		return
+	}
+
+	renderer, err := report.Get(report.Format(*format))
+	if err != nil {
+		log.Fatalln(err)
+	}
+
+	_, manifestIssues, err := eob.Walk(osFs, *targetDir, *verify, true, *currency)
+	if err != nil {
+		log.Fatalln(err)
+	}
+
+	rep := eob.Report{Errors: manifestIssues}
+	if err := renderer.Render(os.Stdout, rep); err != nil {
+		log.Fatalln(err)
+	}
+
+	if rep.HasErrors() {
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,50 @@
+// Command eob-check reconciles EOB (explanation of benefits) files against
+// their paired check files. See each subcommand's -h for its flags.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+)
+
+var subcommands = map[string]func([]string){
+	"verify":   runVerify,
+	"report":   runReport,
+	"checksum": runChecksum,
+	"scaffold": runScaffold,
+}
+
+func main() {
+	if len(os.Args) < 2 {
+		usage()
+		os.Exit(1)
+	}
+
+	cmd, ok := subcommands[os.Args[1]]
+	if !ok {
+		fmt.Fprintf(os.Stderr, "eob-check: unknown subcommand %q\n", os.Args[1])
+		usage()
+		os.Exit(1)
+	}
+
+	cmd(os.Args[2:])
+}
+
+func usage() {
+	fmt.Fprintln(os.Stderr, "usage: eob-check <subcommand> [flags]")
+	fmt.Fprintln(os.Stderr, "subcommands:")
+	fmt.Fprintln(os.Stderr, "  verify    reconcile a single directory of EOB/check files (default behavior)")
+	fmt.Fprintln(os.Stderr, "  report    aggregate totals across many directories")
+	fmt.Fprintln(os.Stderr, "  checksum  emit or verify a sha256 manifest for a directory")
+	fmt.Fprintln(os.Stderr, "  scaffold  generate a spec skeleton from an existing directory")
+}
+
+func newFlagSet(name string) *flag.FlagSet {
+	fs := flag.NewFlagSet(name, flag.ExitOnError)
+	fs.Usage = func() {
+		fmt.Fprintf(os.Stderr, "usage: eob-check %s [flags]\n", name)
+		fs.PrintDefaults()
+	}
+	return fs
+}
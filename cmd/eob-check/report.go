@@ -0,0 +1,57 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/shopspring/decimal"
+	"github.com/spf13/afero"
+
+	"github.com/franz-deleon/eob-check/internal/eob"
+	"github.com/franz-deleon/eob-check/internal/report"
+)
+
+// runReport aggregates totals across many directories, each reconciled
+// independently via filename parsing.
+func runReport(args []string) {
+	fs := newFlagSet("report")
+	currency := fs.String("currency", "USD", "ISO 4217 currency of the amounts encoded in filenames")
+	format := fs.String("format", "text", "Output format: text, json, csv or junit")
+	fs.Parse(args)
+
+	dirs := fs.Args()
+	if len(dirs) == 0 {
+		fmt.Fprintln(os.Stderr, "usage: eob-check report [flags] <dir> [dir...]")
+		os.Exit(1)
+	}
+
+	renderer, err := report.Get(report.Format(*format))
+	if err != nil {
+		log.Fatalln(err)
+	}
+
+	osFs := afero.NewOsFs()
+	var combined eob.Report
+	combined.SetTotal, _ = decimal.NewFromString("0.00")
+
+	for _, dir := range dirs {
+		eobs, _, err := eob.Walk(osFs, dir, "", false, *currency)
+		if err != nil {
+			log.Fatalln(err)
+		}
+
+		rep := eobs.CheckIntegrity()
+		combined.SetTotal = combined.SetTotal.Add(rep.SetTotal)
+		combined.Sets = append(combined.Sets, rep.Sets...)
+		combined.Errors = append(combined.Errors, rep.Errors...)
+	}
+
+	if err := renderer.Render(os.Stdout, combined); err != nil {
+		log.Fatalln(err)
+	}
+
+	if combined.HasErrors() {
+		os.Exit(1)
+	}
+}
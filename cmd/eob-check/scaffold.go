@@ -0,0 +1,86 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"sort"
+
+	"github.com/spf13/afero"
+	"gopkg.in/yaml.v3"
+
+	"github.com/franz-deleon/eob-check/internal/eob"
+	"github.com/franz-deleon/eob-check/internal/money"
+)
+
+// runScaffold parses --dir by filename, the same way verify does, and
+// prints a spec skeleton an operator can hand-edit and pass back via
+// verify --spec.
+func runScaffold(args []string) {
+	fs := newFlagSet("scaffold")
+	targetDir := fs.String("dir", "storage", "The target dir to scaffold a spec from")
+	currency := fs.String("currency", "USD", "ISO 4217 currency of the amounts encoded in filenames")
+	format := fs.String("format", "yaml", "Output format: yaml or json")
+	fs.Parse(args)
+
+	osFs := afero.NewOsFs()
+	eobs, _, err := eob.Walk(osFs, *targetDir, "", false, *currency)
+	if err != nil {
+		log.Fatalln(err)
+	}
+
+	spec := specFromEOBs(eobs)
+
+	switch *format {
+	case "json":
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(spec); err != nil {
+			log.Fatalln(err)
+		}
+	case "yaml":
+		enc := yaml.NewEncoder(os.Stdout)
+		defer enc.Close()
+		if err := enc.Encode(spec); err != nil {
+			log.Fatalln(err)
+		}
+	default:
+		fmt.Fprintf(os.Stderr, "eob-check scaffold: unknown --format %q, want yaml or json\n", *format)
+		os.Exit(1)
+	}
+}
+
+// specFromEOBs converts a filename-derived Type into a Spec skeleton,
+// keyed in a stable order so repeated runs diff cleanly.
+func specFromEOBs(eobs eob.Type) eob.Spec {
+	keys := make([]string, 0, len(eobs))
+	for k := range eobs {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	spec := eob.Spec{}
+	for _, key := range keys {
+		e := eobs[key]
+
+		units, _ := money.MinorUnits(e.Currency)
+
+		set := eob.SetSpec{
+			CheckNumber: e.CheckNumber,
+			CheckTotal:  e.CheckTotal.StringFixed(int32(units)),
+			Currency:    e.Currency,
+			CheckFile:   e.CheckFile,
+		}
+		for _, item := range e.Items {
+			set.Patients = append(set.Patients, eob.PatientSpec{
+				Name: item.Name,
+				Paid: item.Paid.StringFixed(int32(units)),
+			})
+		}
+
+		spec.Sets = append(spec.Sets, set)
+	}
+
+	return spec
+}
@@ -0,0 +1,92 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/franz-deleon/eob-check/internal/eob"
+	"github.com/franz-deleon/eob-check/internal/report"
+	"github.com/shopspring/decimal"
+	"github.com/spf13/afero"
+)
+
+// runVerify reconciles a single directory of EOB/check files, either from
+// filenames (the default) or from a declarative --spec.
+func runVerify(args []string) {
+	fs := newFlagSet("verify")
+	total := fs.Float64("total", 0.00, "The expected total for this set of eobs")
+	targetDir := fs.String("dir", "storage", "The target dir to parse the set of eobs")
+	manifest := fs.String("manifest", "", "Path to a sha256sum-compatible manifest to verify file contents against")
+	strictManifest := fs.Bool("strict-manifest", false, "Fail the run if a matched file is missing from --manifest")
+	spec := fs.String("spec", "", "Path to a YAML/JSON spec declaring the expected check sets, instead of deriving them from filenames")
+	currency := fs.String("currency", "USD", "ISO 4217 currency of the amounts encoded in filenames (ignored with --spec, where each set declares its own)")
+	format := fs.String("format", "text", "Output format: text, json, csv or junit")
+	fs.Parse(args)
+
+	renderer, err := report.Get(report.Format(*format))
+	if err != nil {
+		log.Fatalln(err)
+	}
+
+	osFs := afero.NewOsFs()
+
+	var rep eob.Report
+	if *spec != "" {
+		rep = verifyWithSpec(osFs, *targetDir, *spec)
+	} else {
+		if *total == 0.00 {
+			fmt.Println("--total is required. For help: --help")
+			os.Exit(0)
+		}
+		rep = verifyFromFilenames(osFs, *targetDir, decimal.NewFromFloat(*total), *manifest, *strictManifest, *currency)
+	}
+
+	if err := renderer.Render(os.Stdout, rep); err != nil {
+		log.Fatalln(err)
+	}
+
+	if rep.HasErrors() {
+		os.Exit(1)
+	}
+}
+
+// verifyFromFilenames is the original eob-check behavior: the check total
+// and check number are derived from each filename's prefix.
+func verifyFromFilenames(fs afero.Fs, targetDir string, expTotal decimal.Decimal, manifestPath string, strictManifest bool, currency string) eob.Report {
+	eobs, manifestIssues, err := eob.Walk(fs, targetDir, manifestPath, strictManifest, currency)
+	if err != nil {
+		log.Fatalln(err)
+	}
+
+	rep := eobs.CheckIntegrity()
+	rep.Expected = expTotal
+	rep.Errors = append(rep.Errors, manifestIssues...)
+
+	if !rep.SetTotal.Equal(expTotal) {
+		rep.Errors = append(rep.Errors, eob.Issue{
+			Code:    eob.EExpectedTotalMismatch,
+			Message: fmt.Sprintf("The expected total of %v does not equal %v", expTotal, rep.SetTotal),
+		})
+	}
+
+	return rep
+}
+
+// verifyWithSpec builds the expected check sets from the declarative spec
+// at specPath rather than from filename parsing. Unlike verifyFromFilenames,
+// the expected total comes from the spec itself, so there is no separate
+// --total to reconcile against.
+func verifyWithSpec(fs afero.Fs, targetDir, specPath string) eob.Report {
+	spec, err := eob.ReadSpec(fs, specPath)
+	if err != nil {
+		log.Fatalln(err)
+	}
+
+	eobs, specIssues := eob.BuildFromSpec(fs, targetDir, spec)
+
+	rep := eobs.CheckIntegrity()
+	rep.Errors = append(rep.Errors, specIssues...)
+
+	return rep
+}
@@ -0,0 +1,253 @@
+// Package eob holds the domain model shared by every eob-check subcommand:
+// parsing EOB/check filenames, reconciling their totals and walking a
+// target directory through an afero.Fs.
+package eob
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/shopspring/decimal"
+	"github.com/spf13/afero"
+
+	"github.com/franz-deleon/eob-check/internal/money"
+)
+
+const PrefixRegex = `^([0-9]+)-([0-9A-Za-z]+)_`
+
+type Item struct {
+	Name     string
+	Paid     decimal.Decimal
+	Checksum string
+}
+
+type EOB struct {
+	CheckTotal    decimal.Decimal
+	CheckNumber   string
+	CheckFile     string
+	CheckChecksum string
+	Currency      string
+	// FromSpec marks a set built by BuildFromSpec, whose CheckTotal and
+	// CheckNumber are spec-authoritative. CheckIntegrity must not
+	// re-derive and compare them against CheckFile, since a spec's whole
+	// point is tolerating check_file names that don't encode them (or
+	// have gone stale).
+	FromSpec bool
+	Items    []Item
+}
+
+type Type map[string]EOB
+
+// IsInit checks if the EOB has already been initialized, returning the
+// Type's map key.
+func (eobs Type) IsInit(prefix string) (string, bool) {
+	for k := range eobs {
+		if prefix == k {
+			return k, true
+		}
+	}
+	return "", false
+}
+
+// PrefixMatches reports whether name conforms to PrefixRegex.
+func PrefixMatches(name string) bool {
+	compile, err := regexp.Compile(PrefixRegex)
+	if err != nil {
+		return false
+	}
+	return len(compile.FindStringSubmatch(name)) != 0
+}
+
+// Walk walks targetDir on fs, builds up the Type from the matching
+// filenames and returns it alongside any manifest issues. Amount tokens in
+// filenames are assumed to be plain digits in currency, placed per its
+// ISO 4217 minor-unit width (see money.ParseMinorUnits). When manifestPath
+// is non-empty, every matched file is additionally streamed through sha256
+// and checked against the manifest; strictManifest turns an unlisted file
+// into a hard error instead of being skipped.
+func Walk(fs afero.Fs, targetDir, manifestPath string, strictManifest bool, currency string) (Type, []Issue, error) {
+	if _, err := money.MinorUnits(currency); err != nil {
+		return nil, nil, err
+	}
+
+	dir, err := afero.ReadDir(fs, targetDir)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var manifest Manifest
+	if manifestPath != "" {
+		manifest, err = ReadManifest(fs, manifestPath)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
+	EOBs := make(Type)
+	var issues []Issue
+	seenInManifest := make(map[string]bool)
+
+	for _, file := range dir {
+
+		// skip files that does not conform
+		if !PrefixMatches(file.Name()) {
+			continue
+		}
+
+		ps, err := ParsePreAndSuf(file.Name())
+		if err != nil {
+			return nil, nil, err
+		}
+
+		var checksum string
+		if manifest != nil {
+			checksum, err = FileChecksum(fs, targetDir, file.Name())
+			if err != nil {
+				return nil, nil, err
+			}
+			seenInManifest[file.Name()] = true
+
+			want, ok := manifest[file.Name()]
+			if !ok {
+				if strictManifest {
+					issues = append(issues, Issue{EManifestUnlistedFile, fmt.Sprintf("File %s is not listed in the manifest", file.Name())})
+				}
+			} else if want != checksum {
+				issues = append(issues, Issue{
+					EManifestChecksumMismatch,
+					fmt.Sprintf("Checksum mismatch for %s: manifest has %s, computed %s", file.Name(), want, checksum),
+				})
+			}
+		}
+
+		// check if we already initialized this EOB
+		key, init := EOBs.IsInit(ps.Prefix)
+		if !init { // not initialized yet so initialize
+			// extract the check total and check number
+			prefix := strings.Split(ps.Prefix, "-")
+
+			if len(prefix) != 2 {
+				return nil, nil, fmt.Errorf("wrong prefix and suffix for %s", file.Name())
+			}
+
+			chkTotal, err := money.ParseMinorUnits(prefix[0], currency)
+			if err != nil {
+				return nil, nil, err
+			}
+
+			newEob := EOB{
+				CheckTotal:  chkTotal,
+				CheckNumber: prefix[1],
+				Currency:    currency,
+			}
+
+			if ps.Suffix == "check" {
+				newEob.CheckFile = ps.Suffix
+			}
+
+			key = ps.Prefix
+			EOBs[key] = newEob
+		}
+
+		if ps.Suffix == "check" {
+			if entry, ok := EOBs[key]; ok {
+				entry.CheckFile = file.Name()
+				entry.CheckChecksum = checksum
+				EOBs[key] = entry // we need to reassign entry. ugh
+			}
+		} else {
+			// this should be the EOBs
+			items, err := ParseEOBItems(ps.Suffix, currency)
+			if err != nil {
+				return nil, nil, err
+			}
+			if entry, ok := EOBs[key]; ok {
+				for _, item := range items {
+					item.Checksum = checksum
+					entry.Items = append(entry.Items, item)
+				}
+				EOBs[key] = entry
+			}
+		}
+	}
+
+	if manifest != nil {
+		for name := range manifest {
+			if !seenInManifest[name] {
+				issues = append(issues, Issue{
+					EManifestMissingEntry,
+					fmt.Sprintf("Manifest entry %s does not match any file in %s", name, targetDir),
+				})
+			}
+		}
+	}
+
+	return EOBs, issues, nil
+}
+
+type PreSuf struct {
+	Prefix string
+	Suffix string
+}
+
+// eobPrefix and checkExt/eobExt bound the slicing below: a well-formed
+// suffix is either "check<ext>" or "EOB_<name><ext>".
+const eobPrefix = "EOB_"
+const extLen = 4 // e.g. ".pdf"
+
+func ParsePreAndSuf(filename string) (*PreSuf, error) {
+	compile, err := regexp.Compile(PrefixRegex)
+	if err != nil {
+		return nil, fmt.Errorf("incorrect prefix for file:%s", filename)
+	}
+	reg := compile.FindStringSubmatch(filename)
+	if len(reg) == 0 {
+		return nil, fmt.Errorf("malformed prefix for file %s", filename)
+	}
+
+	prefix := reg[0]
+	suffix := filename[len(prefix):]
+
+	ps := PreSuf{
+		Prefix: prefix[0 : len(prefix)-1], // trim the "_"
+	}
+
+	// determine if this is a "check" file or EOB
+	switch {
+	case strings.HasPrefix(suffix, eobPrefix) && len(suffix) >= len(eobPrefix)+extLen:
+		ps.Suffix = suffix[len(eobPrefix) : len(suffix)-extLen] // remove "EOB_" and the file extension
+	case strings.HasPrefix(suffix, "check") && len(suffix) >= extLen:
+		ps.Suffix = suffix[0 : len(suffix)-extLen] // remove the file extension
+	default:
+		return nil, fmt.Errorf("malformed suffix %q for file %s: expected a check or EOB_ file", suffix, filename)
+	}
+
+	return &ps, nil
+}
+
+// ParseEOBItems parses an EOB file's suffix (ParsePreAndSuf's Suffix,
+// stripped of the "EOB_" marker and file extension) into its Name/Paid
+// pairs. A suffix may encode several patients as alternating name/amount
+// segments, e.g. "JohnDoe_10000_JaneDoe_5000".
+func ParseEOBItems(suffix, currency string) ([]Item, error) {
+	fields := strings.Split(suffix, "_")
+
+	var items []Item
+	item := Item{}
+	for i, val := range fields {
+		if (i+1)%2 == 0 { // this is the currency payment
+			paid, err := money.ParseMinorUnits(val, currency)
+			if err != nil {
+				return nil, err
+			}
+			item.Paid = paid
+			items = append(items, item)
+			item = Item{}
+		} else { // this is the fullname
+			item.Name = val
+		}
+	}
+
+	return items, nil
+}
@@ -0,0 +1,268 @@
+package eob
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/spf13/afero"
+)
+
+func writeFile(t *testing.T, fs afero.Fs, dir, name string) {
+	t.Helper()
+	if err := afero.WriteFile(fs, dir+"/"+name, []byte("test"), 0644); err != nil {
+		t.Fatalf("writeFile(%s): %v", name, err)
+	}
+}
+
+func TestCheckIntegrity_NoErrors(t *testing.T) {
+	eobs := Type{
+		"10000-1001": EOB{
+			CheckTotal:  decimal.RequireFromString("100.00"),
+			CheckNumber: "1001",
+			CheckFile:   "10000-1001_check.pdf",
+			Items: []Item{
+				{Name: "JohnDoe", Paid: decimal.RequireFromString("100.00")},
+			},
+		},
+	}
+
+	rep := eobs.CheckIntegrity()
+	if rep.HasErrors() {
+		t.Fatalf("expected no errors, got %+v", rep.Sets)
+	}
+	if !rep.SetTotal.Equal(decimal.RequireFromString("100.00")) {
+		t.Fatalf("expected total 100.00, got %v", rep.SetTotal)
+	}
+}
+
+func TestCheckIntegrity_MismatchedTotal(t *testing.T) {
+	eobs := Type{
+		"10000-1001": EOB{
+			CheckTotal:  decimal.RequireFromString("100.00"),
+			CheckNumber: "1001",
+			CheckFile:   "10000-1001_check.pdf",
+			Items: []Item{
+				{Name: "JohnDoe", Paid: decimal.RequireFromString("50.00")},
+			},
+		},
+	}
+
+	rep := eobs.CheckIntegrity()
+	if !rep.HasErrors() {
+		t.Fatal("expected a mismatch error, got none")
+	}
+}
+
+func TestCheckIntegrity_MissingCheckFile(t *testing.T) {
+	eobs := Type{
+		"10000-1001": EOB{
+			CheckTotal:  decimal.RequireFromString("100.00"),
+			CheckNumber: "1001",
+			Items: []Item{
+				{Name: "JohnDoe", Paid: decimal.RequireFromString("100.00")},
+			},
+		},
+	}
+
+	rep := eobs.CheckIntegrity()
+	if !rep.HasErrors() {
+		t.Fatal("expected a missing check file error, got none")
+	}
+}
+
+func TestCheckIntegrity_MixedCurrency(t *testing.T) {
+	eobs := Type{
+		"10000-1001": EOB{
+			CheckTotal:  decimal.RequireFromString("100.00"),
+			CheckNumber: "1001",
+			CheckFile:   "10000-1001_check.pdf",
+			Currency:    "USD",
+			Items: []Item{
+				{Name: "JohnDoe", Paid: decimal.RequireFromString("100.00")},
+			},
+		},
+		"20000-1002": EOB{
+			CheckTotal:  decimal.RequireFromString("100.00"),
+			CheckNumber: "1002",
+			CheckFile:   "20000-1002_check.pdf",
+			Currency:    "JPY",
+			Items: []Item{
+				{Name: "JaneDoe", Paid: decimal.RequireFromString("100.00")},
+			},
+		},
+	}
+
+	rep := eobs.CheckIntegrity()
+	found := false
+	for _, issue := range rep.Errors {
+		if issue.Code == EMixedCurrency {
+			found = true
+		}
+	}
+	if !found {
+		t.Fatalf("expected an %s issue, got %+v", EMixedCurrency, rep.Errors)
+	}
+}
+
+func TestWalk_JPYHasNoMinorUnits(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	dir := "storage"
+	writeFile(t, fs, dir, "1000-1001_check.pdf")
+	writeFile(t, fs, dir, "1000-1001_EOB_JohnDoe_1000.pdf")
+
+	eobs, _, err := Walk(fs, dir, "", false, "JPY")
+	if err != nil {
+		t.Fatalf("Walk: %v", err)
+	}
+
+	rep := eobs.CheckIntegrity()
+	if rep.HasErrors() {
+		t.Fatalf("expected no integrity errors, got %+v", rep.Sets)
+	}
+	if !rep.SetTotal.Equal(decimal.RequireFromString("1000")) {
+		t.Fatalf("expected total 1000, got %v", rep.SetTotal)
+	}
+}
+
+func TestWalk_UnknownCurrency(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	dir := "storage"
+	writeFile(t, fs, dir, "10000-1001_check.pdf")
+
+	if _, _, err := Walk(fs, dir, "", false, "NOPE"); err == nil {
+		t.Fatal("expected an error for an unknown currency, got none")
+	}
+}
+
+func TestParsePreAndSuf_MalformedSuffix(t *testing.T) {
+	if _, err := ParsePreAndSuf("10000-1001_ab"); err == nil {
+		t.Fatal("expected an error for a malformed suffix, got none")
+	}
+}
+
+func TestWalk_MalformedSuffixDoesNotPanic(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	dir := "storage"
+	writeFile(t, fs, dir, "10000-1001_ab")
+
+	if _, _, err := Walk(fs, dir, "", false, "USD"); err == nil {
+		t.Fatal("expected an error for a malformed suffix, got none")
+	}
+}
+
+func TestParsePreAndSuf(t *testing.T) {
+	cases := []struct {
+		name       string
+		wantPrefix string
+		wantSuffix string
+	}{
+		{"10000-1001_check.pdf", "10000-1001", "check"},
+		{"10000-1001_EOB_JohnDoe_10000.pdf", "10000-1001", "JohnDoe_10000"},
+	}
+
+	for _, c := range cases {
+		ps, err := ParsePreAndSuf(c.name)
+		if err != nil {
+			t.Fatalf("ParsePreAndSuf(%s): %v", c.name, err)
+		}
+		if ps.Prefix != c.wantPrefix {
+			t.Errorf("prefix = %q, want %q", ps.Prefix, c.wantPrefix)
+		}
+		if ps.Suffix != c.wantSuffix {
+			t.Errorf("suffix = %q, want %q", ps.Suffix, c.wantSuffix)
+		}
+	}
+}
+
+func TestWalk_MemMapFs(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	dir := "storage"
+	writeFile(t, fs, dir, "10000-1001_check.pdf")
+	writeFile(t, fs, dir, "10000-1001_EOB_JohnDoe_10000.pdf")
+	writeFile(t, fs, dir, "ignored.txt")
+
+	eobs, errs, err := Walk(fs, dir, "", false, "USD")
+	if err != nil {
+		t.Fatalf("Walk: %v", err)
+	}
+	if len(errs) != 0 {
+		t.Fatalf("expected no manifest errors, got %v", errs)
+	}
+
+	rep := eobs.CheckIntegrity()
+	if rep.HasErrors() {
+		t.Fatalf("expected no integrity errors, got %+v", rep.Sets)
+	}
+	if !rep.SetTotal.Equal(decimal.RequireFromString("100.00")) {
+		t.Fatalf("expected total 100.00, got %v", rep.SetTotal)
+	}
+}
+
+func TestWalk_ManifestChecksumMismatch(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	dir := "storage"
+	writeFile(t, fs, dir, "10000-1001_check.pdf")
+	writeFile(t, fs, dir, "10000-1001_EOB_JohnDoe_10000.pdf")
+
+	sum, err := FileChecksum(fs, dir, "10000-1001_check.pdf")
+	if err != nil {
+		t.Fatalf("FileChecksum: %v", err)
+	}
+
+	manifestPath := dir + "/manifest.sha256"
+	manifestBody := fmt.Sprintf("%s  10000-1001_check.pdf\nnotarealchecksum  10000-1001_EOB_JohnDoe_10000.pdf\n", sum)
+	if err := afero.WriteFile(fs, manifestPath, []byte(manifestBody), 0644); err != nil {
+		t.Fatalf("write manifest: %v", err)
+	}
+
+	_, errs, err := Walk(fs, dir, manifestPath, false, "USD")
+	if err != nil {
+		t.Fatalf("Walk: %v", err)
+	}
+	if len(errs) == 0 {
+		t.Fatal("expected a checksum mismatch error, got none")
+	}
+}
+
+func TestReadManifest(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	path := "storage/manifest.sha256"
+	body := "abc123  10000-1001_check.pdf\nDEF456  10000-1001_EOB_JohnDoe_10000.pdf\n"
+	if err := afero.WriteFile(fs, path, []byte(body), 0644); err != nil {
+		t.Fatalf("write manifest: %v", err)
+	}
+
+	manifest, err := ReadManifest(fs, path)
+	if err != nil {
+		t.Fatalf("ReadManifest: %v", err)
+	}
+	if manifest["10000-1001_check.pdf"] != "abc123" {
+		t.Errorf("got %q, want %q", manifest["10000-1001_check.pdf"], "abc123")
+	}
+	if manifest["10000-1001_EOB_JohnDoe_10000.pdf"] != "def456" {
+		t.Errorf("checksum should be lowercased, got %q", manifest["10000-1001_EOB_JohnDoe_10000.pdf"])
+	}
+}
+
+func TestWriteChecksumManifest(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	dir := "storage"
+	writeFile(t, fs, dir, "10000-1001_check.pdf")
+	writeFile(t, fs, dir, "ignored.txt")
+
+	var buf bytes.Buffer
+	if err := WriteChecksumManifest(fs, dir, &buf); err != nil {
+		t.Fatalf("WriteChecksumManifest: %v", err)
+	}
+
+	out := buf.String()
+	if !strings.Contains(out, "10000-1001_check.pdf") {
+		t.Errorf("expected manifest to contain the matched file, got %q", out)
+	}
+	if strings.Contains(out, "ignored.txt") {
+		t.Errorf("expected manifest to skip non-conforming files, got %q", out)
+	}
+}
@@ -0,0 +1,94 @@
+package eob
+
+import (
+	"bufio"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/spf13/afero"
+)
+
+// Manifest maps a filename to its expected lowercase-hex sha256 checksum, as
+// read from a sidecar file in `sha256sum` format: "<sha256>  <filename>".
+type Manifest map[string]string
+
+// ReadManifest parses the manifest file at path, compatible with the output
+// of `sha256sum`.
+func ReadManifest(fs afero.Fs, path string) (Manifest, error) {
+	f, err := fs.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	manifest := make(Manifest)
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+
+		fields := strings.Fields(line)
+		if len(fields) != 2 {
+			return nil, fmt.Errorf("manifest: malformed line %q, want \"<sha256>  <filename>\"", line)
+		}
+
+		manifest[fields[1]] = strings.ToLower(fields[0])
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return manifest, nil
+}
+
+// FileChecksum streams the file at targetDir/name through sha256 and returns
+// the lowercase-hex digest.
+func FileChecksum(fs afero.Fs, targetDir, name string) (string, error) {
+	f, err := fs.Open(targetDir + "/" + name)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	h := sha256.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// WriteChecksumManifest walks targetDir for files matching PrefixRegex,
+// hashes each one and emits a fresh `sha256sum`-compatible manifest to w.
+func WriteChecksumManifest(fs afero.Fs, targetDir string, w io.Writer) error {
+	dir, err := afero.ReadDir(fs, targetDir)
+	if err != nil {
+		return err
+	}
+
+	names := make([]string, 0, len(dir))
+	for _, file := range dir {
+		if !PrefixMatches(file.Name()) {
+			continue
+		}
+		names = append(names, file.Name())
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		sum, err := FileChecksum(fs, targetDir, name)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(w, "%s  %s\n", sum, name)
+	}
+
+	return nil
+}
@@ -0,0 +1,259 @@
+package eob
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/franz-deleon/eob-check/internal/money"
+)
+
+// Code is a stable identifier for a reconciliation issue, suitable for
+// downstream systems to react to without parsing Message.
+type Code string
+
+const (
+	ECheckTotalMissing  Code = "E_CHECK_TOTAL_MISSING"
+	ENumberMissing      Code = "E_NUMBER_MISSING"
+	EMissingCheckFile   Code = "E_MISSING_CHECK_FILE"
+	ECheckTotalMismatch Code = "E_CHECK_TOTAL_MISMATCH"
+	ENumberMismatch     Code = "E_NUMBER_MISMATCH"
+	EItemsTotalMismatch Code = "E_ITEMS_TOTAL_MISMATCH"
+
+	EManifestChecksumMismatch Code = "E_MANIFEST_CHECKSUM_MISMATCH"
+	EManifestUnlistedFile     Code = "E_MANIFEST_UNLISTED_FILE"
+	EManifestMissingEntry     Code = "E_MANIFEST_MISSING_ENTRY"
+
+	ESpecUncoveredFile    Code = "E_SPEC_UNCOVERED_FILE"
+	ESpecMissingCheckFile Code = "E_SPEC_MISSING_CHECK_FILE"
+	ESpecInvalidAmount    Code = "E_SPEC_INVALID_AMOUNT"
+
+	EExpectedTotalMismatch Code = "E_EXPECTED_TOTAL_MISMATCH"
+	EMixedCurrency         Code = "E_MIXED_CURRENCY"
+)
+
+// Issue is one reconciliation problem found for a set or for the run as a
+// whole.
+type Issue struct {
+	Code    Code
+	Message string
+}
+
+// SetReport is the reconciliation result for a single check set.
+type SetReport struct {
+	Key         string
+	CheckNumber string
+	CheckTotal  decimal.Decimal
+	ItemsTotal  decimal.Decimal
+	CheckFile   string
+	Currency    string
+	Errors      []Issue
+}
+
+// setReportJSON mirrors SetReport but renders CheckTotal/ItemsTotal in the
+// canonical money.Amount {"amount":"...","currency":"..."} shape, paired
+// with the set's own Currency.
+type setReportJSON struct {
+	Key         string
+	CheckNumber string
+	CheckTotal  money.Amount
+	ItemsTotal  money.Amount
+	CheckFile   string
+	Currency    string
+	Errors      []Issue
+}
+
+func (s SetReport) MarshalJSON() ([]byte, error) {
+	return json.Marshal(setReportJSON{
+		Key:         s.Key,
+		CheckNumber: s.CheckNumber,
+		CheckTotal:  money.Amount{Value: s.CheckTotal, Currency: s.Currency},
+		ItemsTotal:  money.Amount{Value: s.ItemsTotal, Currency: s.Currency},
+		CheckFile:   s.CheckFile,
+		Currency:    s.Currency,
+		Errors:      s.Errors,
+	})
+}
+
+func (s *SetReport) UnmarshalJSON(data []byte) error {
+	var aux setReportJSON
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+	*s = SetReport{
+		Key:         aux.Key,
+		CheckNumber: aux.CheckNumber,
+		CheckTotal:  aux.CheckTotal.Value,
+		ItemsTotal:  aux.ItemsTotal.Value,
+		CheckFile:   aux.CheckFile,
+		Currency:    aux.Currency,
+		Errors:      aux.Errors,
+	}
+	return nil
+}
+
+// Report is the reconciliation result for a whole run: every set, the
+// grand total of their item totals, the externally expected total (if
+// any), and issues that don't belong to a single set (manifest/spec
+// coverage problems, a mismatched --total).
+type Report struct {
+	SetTotal decimal.Decimal
+	Expected decimal.Decimal
+	Sets     []SetReport
+	Errors   []Issue
+}
+
+// reportCurrency is the currency used to render Report.SetTotal and
+// Report.Expected in JSON, since those fields have no currency of their
+// own: the first set's, defaulting to USD when there are no sets.
+func (r Report) reportCurrency() string {
+	if len(r.Sets) > 0 && r.Sets[0].Currency != "" {
+		return r.Sets[0].Currency
+	}
+	return "USD"
+}
+
+type reportJSON struct {
+	SetTotal money.Amount
+	Expected money.Amount
+	Sets     []SetReport
+	Errors   []Issue
+}
+
+func (r Report) MarshalJSON() ([]byte, error) {
+	currency := r.reportCurrency()
+	return json.Marshal(reportJSON{
+		SetTotal: money.Amount{Value: r.SetTotal, Currency: currency},
+		Expected: money.Amount{Value: r.Expected, Currency: currency},
+		Sets:     r.Sets,
+		Errors:   r.Errors,
+	})
+}
+
+func (r *Report) UnmarshalJSON(data []byte) error {
+	var aux reportJSON
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+	*r = Report{
+		SetTotal: aux.SetTotal.Value,
+		Expected: aux.Expected.Value,
+		Sets:     aux.Sets,
+		Errors:   aux.Errors,
+	}
+	return nil
+}
+
+// HasErrors reports whether the report, or any of its sets, carries an
+// issue.
+func (r Report) HasErrors() bool {
+	if len(r.Errors) > 0 {
+		return true
+	}
+	for _, s := range r.Sets {
+		if len(s.Errors) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// CheckIntegrity reconciles every set in eobs and returns a structured
+// Report: each set's item total against its check total, and the check
+// file's encoded total/number against the set's.
+func (eobs Type) CheckIntegrity() Report {
+	var report Report
+
+	zero, _ := decimal.NewFromString("0.00")
+	report.SetTotal = zero
+
+	for key, eob := range eobs {
+		set := SetReport{
+			Key:         key,
+			CheckNumber: eob.CheckNumber,
+			CheckTotal:  eob.CheckTotal,
+			CheckFile:   eob.CheckFile,
+			Currency:    eob.Currency,
+			ItemsTotal:  zero,
+		}
+
+		if eob.CheckTotal.LessThanOrEqual(decimal.NewFromInt(0)) {
+			set.Errors = append(set.Errors, Issue{ECheckTotalMissing, "There is no check total for " + key})
+		}
+		if eob.CheckNumber == "" {
+			set.Errors = append(set.Errors, Issue{ENumberMissing, "Check number does not exist for " + key})
+		}
+		if eob.CheckFile == "" {
+			set.Errors = append(set.Errors, Issue{EMissingCheckFile, "Missing check file for " + key})
+		} else if eob.FromSpec {
+			// Spec-sourced sets' CheckTotal/CheckNumber are already
+			// spec-authoritative; don't re-derive and compare them against
+			// CheckFile, which may be freeform or stale by design.
+		} else if chkSplit := strings.Split(eob.CheckFile, "-"); len(chkSplit) >= 2 {
+			// A check_file with fewer than two "-"-separated parts carries
+			// no filename-encoded total/number (e.g. a non-conforming
+			// name); there is nothing to reconcile it against.
+			currency := eob.Currency
+			if currency == "" {
+				currency = "USD"
+			}
+			chkTotal, _ := money.ParseMinorUnits(chkSplit[0], currency)
+			if !chkTotal.Equals(eob.CheckTotal) {
+				set.Errors = append(set.Errors, Issue{
+					ECheckTotalMismatch,
+					fmt.Sprintf("Check total does not match between set %s and file %s", key, eob.CheckFile),
+				})
+			}
+			chkNum := strings.Split(chkSplit[1], "_")[0]
+			if chkNum != eob.CheckNumber {
+				set.Errors = append(set.Errors, Issue{
+					ENumberMismatch,
+					fmt.Sprintf("Check number does not match for file %s", eob.CheckFile),
+				})
+			}
+		}
+
+		for _, item := range eob.Items {
+			set.ItemsTotal = set.ItemsTotal.Add(item.Paid)
+		}
+		if !set.ItemsTotal.Equals(eob.CheckTotal) {
+			set.Errors = append(set.Errors, Issue{
+				EItemsTotalMismatch,
+				fmt.Sprintf("Check total %v does not match item totals %v for %s", eob.CheckTotal, set.ItemsTotal, key),
+			})
+		}
+
+		report.SetTotal = report.SetTotal.Add(set.ItemsTotal)
+		report.Sets = append(report.Sets, set)
+	}
+
+	if mixed, currencies := mixedCurrencies(report.Sets); mixed {
+		report.Errors = append(report.Errors, Issue{
+			EMixedCurrency,
+			fmt.Sprintf("Run mixes currencies %s; totals are not comparable across sets", strings.Join(currencies, ", ")),
+		})
+	}
+
+	return report
+}
+
+// mixedCurrencies reports whether sets declare more than one distinct
+// currency, along with the distinct currencies found (blank treated as
+// "USD", matching CheckIntegrity's own default).
+func mixedCurrencies(sets []SetReport) (bool, []string) {
+	seen := make(map[string]bool)
+	var currencies []string
+	for _, s := range sets {
+		c := s.Currency
+		if c == "" {
+			c = "USD"
+		}
+		if !seen[c] {
+			seen[c] = true
+			currencies = append(currencies, c)
+		}
+	}
+	return len(currencies) > 1, currencies
+}
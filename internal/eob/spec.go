@@ -0,0 +1,218 @@
+package eob
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/shopspring/decimal"
+	"github.com/spf13/afero"
+	"gopkg.in/yaml.v3"
+
+	"github.com/franz-deleon/eob-check/internal/money"
+)
+
+// Spec declares a set of checks explicitly, instead of relying on the check
+// total and check number being encoded in filenames.
+type Spec struct {
+	Sets []SetSpec `yaml:"sets" json:"sets"`
+}
+
+// SetSpec is one expected check: its number, total, payer and the patients
+// it is expected to cover.
+type SetSpec struct {
+	CheckNumber string        `yaml:"check_number" json:"check_number"`
+	CheckTotal  string        `yaml:"check_total" json:"check_total"`
+	Currency    string        `yaml:"currency" json:"currency"`
+	Payer       string        `yaml:"payer" json:"payer"`
+	CheckFile   string        `yaml:"check_file" json:"check_file"`
+	Patients    []PatientSpec `yaml:"patients" json:"patients"`
+}
+
+// PatientSpec is one expected patient payment within a check set.
+type PatientSpec struct {
+	Name string `yaml:"name" json:"name"`
+	Paid string `yaml:"paid" json:"paid"`
+}
+
+// ReadSpec parses a spec file, choosing YAML or JSON based on its extension
+// (defaulting to YAML).
+func ReadSpec(fs afero.Fs, path string) (*Spec, error) {
+	data, err := afero.ReadFile(fs, path)
+	if err != nil {
+		return nil, err
+	}
+
+	var spec Spec
+	if strings.ToLower(filepath.Ext(path)) == ".json" {
+		if err := json.Unmarshal(data, &spec); err != nil {
+			return nil, fmt.Errorf("spec: %w", err)
+		}
+	} else {
+		if err := yaml.Unmarshal(data, &spec); err != nil {
+			return nil, fmt.Errorf("spec: %w", err)
+		}
+	}
+
+	return &spec, nil
+}
+
+// BuildFromSpec builds a Type straight from spec, rather than from filename
+// parsing, and reconciles each set's check_file against targetDir. Each
+// set's currency defaults to USD when unset; amounts with more fractional
+// digits than their currency's ISO 4217 minor-unit width allows become an
+// Issue. A set's declared patients are also cross-checked against the
+// directory's own EOB files (matched via the check file's "<prefix>-
+// <number>" key, when it has one): a declared patient with no matching EOB
+// file, a mismatched paid amount, any check_file glob that resolves to no
+// file, and any directory file (check or EOB) that no set accounts for,
+// each become an Issue.
+func BuildFromSpec(fs afero.Fs, targetDir string, spec *Spec) (Type, []Issue) {
+	var issues []Issue
+	EOBs := make(Type)
+	covered := make(map[string]bool)
+
+	dir, err := afero.ReadDir(fs, targetDir)
+	if err != nil {
+		issues = append(issues, Issue{ESpecUncoveredFile, fmt.Sprintf("Could not read %s: %v", targetDir, err)})
+		return EOBs, issues
+	}
+
+	diskItems := groupDiskItemsByPrefix(dir)
+
+	for _, set := range spec.Sets {
+		currency := set.Currency
+		if currency == "" {
+			currency = "USD"
+		}
+		if _, err := money.MinorUnits(currency); err != nil {
+			issues = append(issues, Issue{ESpecInvalidAmount, fmt.Sprintf("Invalid currency %q for check %s: %v", set.Currency, set.CheckNumber, err)})
+			continue
+		}
+
+		chkTotal, err := decimal.NewFromString(set.CheckTotal)
+		if err != nil {
+			issues = append(issues, Issue{ESpecInvalidAmount, fmt.Sprintf("Invalid check_total %q for check %s: %v", set.CheckTotal, set.CheckNumber, err)})
+			continue
+		}
+		if err := money.ValidateScale(chkTotal, currency); err != nil {
+			issues = append(issues, Issue{ESpecInvalidAmount, fmt.Sprintf("Invalid check_total %q for check %s: %v", set.CheckTotal, set.CheckNumber, err)})
+			continue
+		}
+
+		eob := EOB{
+			CheckTotal:  chkTotal,
+			CheckNumber: set.CheckNumber,
+			Currency:    currency,
+			FromSpec:    true,
+		}
+
+		matches, err := afero.Glob(fs, filepath.Join(targetDir, set.CheckFile))
+		var setDiskItems []diskItem
+		if err != nil {
+			issues = append(issues, Issue{ESpecMissingCheckFile, fmt.Sprintf("Invalid check_file glob %q for check %s: %v", set.CheckFile, set.CheckNumber, err)})
+		} else if len(matches) == 0 {
+			issues = append(issues, Issue{ESpecMissingCheckFile, fmt.Sprintf("Check file %q for check %s was not found in %s", set.CheckFile, set.CheckNumber, targetDir)})
+		} else if len(matches) > 1 {
+			issues = append(issues, Issue{ESpecMissingCheckFile, fmt.Sprintf("Check file glob %q for check %s matched %d files, expected 1", set.CheckFile, set.CheckNumber, len(matches))})
+		} else {
+			name := filepath.Base(matches[0])
+			eob.CheckFile = name
+			covered[name] = true
+			if ps, err := ParsePreAndSuf(name); err == nil {
+				setDiskItems = diskItems[ps.Prefix]
+			}
+		}
+
+		for _, patient := range set.Patients {
+			paid, err := decimal.NewFromString(patient.Paid)
+			if err != nil {
+				issues = append(issues, Issue{ESpecInvalidAmount, fmt.Sprintf("Invalid paid amount %q for patient %s in check %s: %v", patient.Paid, patient.Name, set.CheckNumber, err)})
+				continue
+			}
+			if err := money.ValidateScale(paid, currency); err != nil {
+				issues = append(issues, Issue{ESpecInvalidAmount, fmt.Sprintf("Invalid paid amount %q for patient %s in check %s: %v", patient.Paid, patient.Name, set.CheckNumber, err)})
+				continue
+			}
+			eob.Items = append(eob.Items, Item{Name: patient.Name, Paid: paid})
+
+			if setDiskItems == nil {
+				continue
+			}
+			match := findDiskItem(setDiskItems, patient.Name)
+			if match == nil {
+				issues = append(issues, Issue{ESpecUncoveredFile, fmt.Sprintf("Patient %s declared for check %s has no matching EOB file in %s", patient.Name, set.CheckNumber, targetDir)})
+				continue
+			}
+			covered[match.File] = true
+			if !match.Item.Paid.Equal(paid) {
+				issues = append(issues, Issue{
+					ESpecInvalidAmount,
+					fmt.Sprintf("EOB file %s declares %v paid for %s, check %s's spec declares %v", match.File, match.Item.Paid, patient.Name, set.CheckNumber, paid),
+				})
+			}
+		}
+
+		EOBs[set.CheckNumber] = eob
+	}
+
+	for _, file := range dir {
+		if !PrefixMatches(file.Name()) {
+			continue
+		}
+		if !covered[file.Name()] {
+			issues = append(issues, Issue{ESpecUncoveredFile, fmt.Sprintf("File %s in %s is not covered by any check in the spec", file.Name(), targetDir)})
+		}
+	}
+
+	return EOBs, issues
+}
+
+// diskItem is one patient item parsed from a directory's EOB files, kept
+// alongside the filename it came from so BuildFromSpec can mark that file
+// covered once it's matched to a spec-declared patient.
+type diskItem struct {
+	Item
+	File string
+}
+
+// groupDiskItemsByPrefix parses every EOB file in dir (skipping check
+// files and anything that doesn't conform to PrefixRegex) into its
+// patient items, grouped by the "<prefix>-<number>" key it shares with a
+// check file. Unparseable items are skipped; BuildFromSpec's final
+// uncovered-file scan still catches them.
+func groupDiskItemsByPrefix(dir []os.FileInfo) map[string][]diskItem {
+	grouped := make(map[string][]diskItem)
+	for _, file := range dir {
+		if !PrefixMatches(file.Name()) {
+			continue
+		}
+		ps, err := ParsePreAndSuf(file.Name())
+		if err != nil || ps.Suffix == "check" {
+			continue
+		}
+		// The items' currency doesn't matter here: only the declared
+		// name is used to find a match, the amount is re-parsed against
+		// the owning set's own currency once matched.
+		items, err := ParseEOBItems(ps.Suffix, "USD")
+		if err != nil {
+			continue
+		}
+		for _, item := range items {
+			grouped[ps.Prefix] = append(grouped[ps.Prefix], diskItem{Item: item, File: file.Name()})
+		}
+	}
+	return grouped
+}
+
+// findDiskItem returns the first item in items named name, or nil.
+func findDiskItem(items []diskItem, name string) *diskItem {
+	for i, item := range items {
+		if item.Name == name {
+			return &items[i]
+		}
+	}
+	return nil
+}
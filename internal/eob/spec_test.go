@@ -0,0 +1,326 @@
+package eob
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/spf13/afero"
+)
+
+func TestReadSpec_YAML(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	path := "spec.yaml"
+	body := `
+sets:
+  - check_number: "1001"
+    check_total: "100.00"
+    currency: USD
+    payer: Acme Insurance
+    check_file: "10000-1001_check.pdf"
+    patients:
+      - name: JohnDoe
+        paid: "100.00"
+`
+	if err := afero.WriteFile(fs, path, []byte(body), 0644); err != nil {
+		t.Fatalf("write spec: %v", err)
+	}
+
+	spec, err := ReadSpec(fs, path)
+	if err != nil {
+		t.Fatalf("ReadSpec: %v", err)
+	}
+	if len(spec.Sets) != 1 || spec.Sets[0].CheckNumber != "1001" {
+		t.Fatalf("unexpected spec: %+v", spec)
+	}
+}
+
+func TestReadSpec_JSON(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	path := "spec.json"
+	body := `{"sets":[{"check_number":"1001","check_total":"100.00","check_file":"10000-1001_check.pdf","patients":[{"name":"JohnDoe","paid":"100.00"}]}]}`
+	if err := afero.WriteFile(fs, path, []byte(body), 0644); err != nil {
+		t.Fatalf("write spec: %v", err)
+	}
+
+	spec, err := ReadSpec(fs, path)
+	if err != nil {
+		t.Fatalf("ReadSpec: %v", err)
+	}
+	if len(spec.Sets) != 1 || spec.Sets[0].CheckNumber != "1001" {
+		t.Fatalf("unexpected spec: %+v", spec)
+	}
+}
+
+func TestBuildFromSpec(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	dir := "storage"
+	writeFile(t, fs, dir, "10000-1001_check.pdf")
+
+	spec := &Spec{
+		Sets: []SetSpec{
+			{
+				CheckNumber: "1001",
+				CheckTotal:  "100.00",
+				CheckFile:   "10000-1001_check.pdf",
+				Patients: []PatientSpec{
+					{Name: "JohnDoe", Paid: "100.00"},
+				},
+			},
+		},
+	}
+
+	eobs, errs := BuildFromSpec(fs, dir, spec)
+	if len(errs) != 0 {
+		t.Fatalf("expected no errors, got %v", errs)
+	}
+	if _, ok := eobs["1001"]; !ok {
+		t.Fatalf("expected eobs to contain check 1001, got %v", eobs)
+	}
+}
+
+func TestBuildFromSpec_InvalidAmountForCurrency(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	dir := "storage"
+	writeFile(t, fs, dir, "10000-1001_check.pdf")
+
+	spec := &Spec{
+		Sets: []SetSpec{
+			{
+				CheckNumber: "1001",
+				CheckTotal:  "100.005",
+				Currency:    "USD",
+				CheckFile:   "10000-1001_check.pdf",
+			},
+		},
+	}
+
+	_, errs := BuildFromSpec(fs, dir, spec)
+	if len(errs) == 0 {
+		t.Fatal("expected an error for a check_total with too many fractional digits for USD, got none")
+	}
+}
+
+func TestBuildFromSpec_UnknownCurrency(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	dir := "storage"
+	writeFile(t, fs, dir, "10000-1001_check.pdf")
+
+	spec := &Spec{
+		Sets: []SetSpec{
+			{
+				CheckNumber: "1001",
+				CheckTotal:  "100.00",
+				Currency:    "NOPE",
+				CheckFile:   "10000-1001_check.pdf",
+			},
+		},
+	}
+
+	_, errs := BuildFromSpec(fs, dir, spec)
+	if len(errs) == 0 {
+		t.Fatal("expected an error for an unknown currency, got none")
+	}
+}
+
+func TestBuildFromSpec_NonEncodedCheckFileDoesNotPanic(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	dir := "storage"
+	writeFile(t, fs, dir, "statement.pdf")
+
+	spec := &Spec{
+		Sets: []SetSpec{
+			{
+				CheckNumber: "1001",
+				CheckTotal:  "100.00",
+				CheckFile:   "statement.pdf",
+				Patients: []PatientSpec{
+					{Name: "JohnDoe", Paid: "100.00"},
+				},
+			},
+		},
+	}
+
+	eobs, errs := BuildFromSpec(fs, dir, spec)
+	if len(errs) != 0 {
+		t.Fatalf("expected no errors, got %v", errs)
+	}
+
+	rep := eobs.CheckIntegrity()
+	if rep.HasErrors() {
+		t.Fatalf("expected no integrity errors for a non-encoded check_file, got %+v", rep.Sets)
+	}
+}
+
+func TestBuildFromSpec_EOBFilesNotFlaggedAsUncovered(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	dir := "storage"
+	writeFile(t, fs, dir, "10000-1001_check.pdf")
+	writeFile(t, fs, dir, "10000-1001_EOB_JohnDoe_10000.pdf")
+
+	spec := &Spec{
+		Sets: []SetSpec{
+			{
+				CheckNumber: "1001",
+				CheckTotal:  "100.00",
+				CheckFile:   "10000-1001_check.pdf",
+				Patients: []PatientSpec{
+					{Name: "JohnDoe", Paid: "100.00"},
+				},
+			},
+		},
+	}
+
+	_, errs := BuildFromSpec(fs, dir, spec)
+	if len(errs) != 0 {
+		t.Fatalf("expected the per-patient EOB file to not be flagged as uncovered, got %v", errs)
+	}
+}
+
+func TestBuildFromSpec_StaleEncodedCheckFileNotReconciled(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	dir := "storage"
+	// The filename-encoded total/number (50.00, 9999) is stale relative to
+	// the spec's own values (100.00, 1001); spec mode must not care.
+	writeFile(t, fs, dir, "5000-9999_check.pdf")
+
+	spec := &Spec{
+		Sets: []SetSpec{
+			{
+				CheckNumber: "1001",
+				CheckTotal:  "100.00",
+				CheckFile:   "5000-9999_check.pdf",
+				Patients: []PatientSpec{
+					{Name: "JohnDoe", Paid: "100.00"},
+				},
+			},
+		},
+	}
+
+	eobs, errs := BuildFromSpec(fs, dir, spec)
+	if len(errs) != 0 {
+		t.Fatalf("expected no errors, got %v", errs)
+	}
+
+	rep := eobs.CheckIntegrity()
+	if rep.HasErrors() {
+		t.Fatalf("expected a stale filename-encoded check_file to be tolerated in spec mode, got %+v", rep.Sets)
+	}
+}
+
+func TestBuildFromSpec_UncoveredFile(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	dir := "storage"
+	writeFile(t, fs, dir, "10000-1001_check.pdf")
+	writeFile(t, fs, dir, "20000-1002_check.pdf")
+
+	spec := &Spec{
+		Sets: []SetSpec{
+			{
+				CheckNumber: "1001",
+				CheckTotal:  "100.00",
+				CheckFile:   "10000-1001_check.pdf",
+			},
+		},
+	}
+
+	_, errs := BuildFromSpec(fs, dir, spec)
+	if len(errs) == 0 {
+		t.Fatal("expected an error for the uncovered file, got none")
+	}
+}
+
+func TestBuildFromSpec_UndeclaredPatientInEOBFile(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	dir := "storage"
+	writeFile(t, fs, dir, "10000-1001_check.pdf")
+	writeFile(t, fs, dir, "10000-1001_EOB_JohnDoe_6000_JaneDoe_4000.pdf")
+	writeFile(t, fs, dir, "5000-2002_EOB_GhostPatient_5000.pdf")
+
+	spec := &Spec{
+		Sets: []SetSpec{
+			{
+				CheckNumber: "1001",
+				CheckTotal:  "100.00",
+				CheckFile:   "10000-1001_check.pdf",
+				Patients: []PatientSpec{
+					{Name: "SomeoneElse", Paid: "100.00"},
+				},
+			},
+		},
+	}
+
+	_, errs := BuildFromSpec(fs, dir, spec)
+	if len(errs) == 0 {
+		t.Fatal("expected errors for the undeclared EOB patients and the unrelated directory file, got none")
+	}
+
+	var gotUncoveredGhost, gotUnmatchedPatient bool
+	for _, issue := range errs {
+		if issue.Code != ESpecUncoveredFile {
+			continue
+		}
+		if strings.Contains(issue.Message, "5000-2002_EOB_GhostPatient_5000.pdf") {
+			gotUncoveredGhost = true
+		}
+		if strings.Contains(issue.Message, "SomeoneElse") {
+			gotUnmatchedPatient = true
+		}
+	}
+	if !gotUncoveredGhost {
+		t.Errorf("expected the unrelated EOB file to be flagged as uncovered, got %v", errs)
+	}
+	if !gotUnmatchedPatient {
+		t.Errorf("expected the spec's unmatched patient to be flagged, got %v", errs)
+	}
+}
+
+func TestBuildFromSpec_AmountMismatchAgainstEOBFile(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	dir := "storage"
+	writeFile(t, fs, dir, "10000-1001_check.pdf")
+	writeFile(t, fs, dir, "10000-1001_EOB_JohnDoe_6000.pdf")
+
+	spec := &Spec{
+		Sets: []SetSpec{
+			{
+				CheckNumber: "1001",
+				CheckTotal:  "100.00",
+				CheckFile:   "10000-1001_check.pdf",
+				Patients: []PatientSpec{
+					{Name: "JohnDoe", Paid: "100.00"},
+				},
+			},
+		},
+	}
+
+	_, errs := BuildFromSpec(fs, dir, spec)
+	found := false
+	for _, issue := range errs {
+		if issue.Code == ESpecInvalidAmount && strings.Contains(issue.Message, "JohnDoe") {
+			found = true
+		}
+	}
+	if !found {
+		t.Fatalf("expected an amount mismatch issue for JohnDoe, got %v", errs)
+	}
+}
+
+func TestBuildFromSpec_MissingCheckFile(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	dir := "storage"
+
+	spec := &Spec{
+		Sets: []SetSpec{
+			{
+				CheckNumber: "1001",
+				CheckTotal:  "100.00",
+				CheckFile:   "10000-1001_check.pdf",
+			},
+		},
+	}
+
+	_, errs := BuildFromSpec(fs, dir, spec)
+	if len(errs) == 0 {
+		t.Fatal("expected an error for the missing check file, got none")
+	}
+}
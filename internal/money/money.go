@@ -0,0 +1,106 @@
+// Package money parses and formats currency amounts, replacing the old
+// assumption that every filename token is a USD amount with two decimal
+// places.
+package money
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/shopspring/decimal"
+	"golang.org/x/text/currency"
+	"golang.org/x/text/language"
+	"golang.org/x/text/message"
+)
+
+// MinorUnits returns code's number of minor-unit digits per ISO 4217 (e.g.
+// 2 for USD, 0 for JPY, 3 for BHD).
+func MinorUnits(code string) (int, error) {
+	unit, err := currency.ParseISO(code)
+	if err != nil {
+		return 0, fmt.Errorf("money: unknown currency %q: %w", code, err)
+	}
+	scale, _ := currency.Standard.Rounding(unit)
+	return scale, nil
+}
+
+// ParseMinorUnits parses a filename token of plain digits (no decimal
+// point) into a decimal amount, placing the decimal point code's
+// minor-unit width from the right. A token shorter than that width is a
+// legitimate sub-unit amount (e.g. "50" is $0.50 in USD) and is left-padded
+// rather than rejected. For a zero-minor-unit currency like JPY, the token
+// is the whole amount as-is.
+func ParseMinorUnits(token, code string) (decimal.Decimal, error) {
+	units, err := MinorUnits(code)
+	if err != nil {
+		return decimal.Decimal{}, err
+	}
+	if units == 0 {
+		return decimal.NewFromString(token)
+	}
+	if len(token) <= units {
+		token = strings.Repeat("0", units+1-len(token)) + token
+	}
+	whole, frac := token[:len(token)-units], token[len(token)-units:]
+	return decimal.NewFromString(whole + "." + frac)
+}
+
+// ValidateScale returns an error if amount has more fractional digits than
+// code's minor-unit width allows (e.g. "1.005" for USD, or "100.5" for
+// JPY).
+func ValidateScale(amount decimal.Decimal, code string) error {
+	units, err := MinorUnits(code)
+	if err != nil {
+		return err
+	}
+	if scale := -amount.Exponent(); scale > int32(units) {
+		return fmt.Errorf("money: %s has %d fractional digit(s), %s allows at most %d", amount.String(), scale, code, units)
+	}
+	return nil
+}
+
+// Amount pairs a decimal value with its ISO 4217 currency code.
+type Amount struct {
+	Value    decimal.Decimal
+	Currency string
+}
+
+// jsonAmount is the canonical {"amount":"123.45","currency":"USD"} shape.
+type jsonAmount struct {
+	Amount   string `json:"amount"`
+	Currency string `json:"currency"`
+}
+
+func (a Amount) MarshalJSON() ([]byte, error) {
+	units, err := MinorUnits(a.Currency)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(jsonAmount{Amount: a.Value.StringFixed(int32(units)), Currency: a.Currency})
+}
+
+func (a *Amount) UnmarshalJSON(data []byte) error {
+	var aux jsonAmount
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+	v, err := decimal.NewFromString(aux.Amount)
+	if err != nil {
+		return err
+	}
+	a.Value = v
+	a.Currency = aux.Currency
+	return nil
+}
+
+// Format renders a for locale, with thousands separators and a currency
+// symbol, e.g. "$1,234.50".
+func (a Amount) Format(locale language.Tag) (string, error) {
+	unit, err := currency.ParseISO(a.Currency)
+	if err != nil {
+		return "", fmt.Errorf("money: unknown currency %q: %w", a.Currency, err)
+	}
+	f, _ := a.Value.Float64()
+	return message.NewPrinter(locale).Sprintf("%v", currency.Symbol(unit.Amount(f))), nil
+}
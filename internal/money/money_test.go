@@ -0,0 +1,122 @@
+package money
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/shopspring/decimal"
+)
+
+func TestMinorUnits(t *testing.T) {
+	cases := map[string]int{"USD": 2, "JPY": 0, "BHD": 3}
+	for code, want := range cases {
+		got, err := MinorUnits(code)
+		if err != nil {
+			t.Fatalf("MinorUnits(%s): %v", code, err)
+		}
+		if got != want {
+			t.Errorf("MinorUnits(%s) = %d, want %d", code, got, want)
+		}
+	}
+}
+
+func TestMinorUnits_UnknownCurrency(t *testing.T) {
+	if _, err := MinorUnits("XXX_NOT_A_CODE"); err == nil {
+		t.Fatal("expected an error for an unknown currency, got none")
+	}
+}
+
+func TestParseMinorUnits(t *testing.T) {
+	cases := []struct {
+		token, code, want string
+	}{
+		{"10050", "USD", "100.50"},
+		{"1005", "JPY", "1005"},
+		{"100050", "BHD", "100.050"},
+	}
+	for _, c := range cases {
+		got, err := ParseMinorUnits(c.token, c.code)
+		if err != nil {
+			t.Fatalf("ParseMinorUnits(%s, %s): %v", c.token, c.code, err)
+		}
+		if !got.Equal(decimal.RequireFromString(c.want)) {
+			t.Errorf("ParseMinorUnits(%s, %s) = %v, want %v", c.token, c.code, got, c.want)
+		}
+	}
+}
+
+func TestParseMinorUnits_SubUnitAmount(t *testing.T) {
+	cases := []struct{ token, want string }{
+		{"50", "0.50"},
+		{"5", "0.05"},
+	}
+	for _, c := range cases {
+		got, err := ParseMinorUnits(c.token, "USD")
+		if err != nil {
+			t.Fatalf("ParseMinorUnits(%s, USD): %v", c.token, err)
+		}
+		if !got.Equal(decimal.RequireFromString(c.want)) {
+			t.Errorf("ParseMinorUnits(%s, USD) = %v, want %v", c.token, got, c.want)
+		}
+	}
+}
+
+func TestValidateScale(t *testing.T) {
+	if err := ValidateScale(decimal.RequireFromString("100.50"), "USD"); err != nil {
+		t.Errorf("expected 100.50 to be valid for USD, got %v", err)
+	}
+	if err := ValidateScale(decimal.RequireFromString("100.005"), "USD"); err == nil {
+		t.Error("expected 100.005 to be invalid for USD, got none")
+	}
+	if err := ValidateScale(decimal.RequireFromString("1005"), "JPY"); err != nil {
+		t.Errorf("expected 1005 to be valid for JPY, got %v", err)
+	}
+}
+
+func TestAmount_JSONRoundTrip(t *testing.T) {
+	a := Amount{Value: decimal.RequireFromString("123.45"), Currency: "USD"}
+
+	data, err := json.Marshal(a)
+	if err != nil {
+		t.Fatalf("Marshal: %v", err)
+	}
+	if string(data) != `{"amount":"123.45","currency":"USD"}` {
+		t.Errorf("got %s, want canonical {amount,currency} shape", data)
+	}
+
+	var decoded Amount
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("Unmarshal: %v", err)
+	}
+	if !decoded.Value.Equal(a.Value) || decoded.Currency != a.Currency {
+		t.Errorf("round-tripped to %+v, want %+v", decoded, a)
+	}
+}
+
+func TestAmount_MarshalJSON_FixedWidth(t *testing.T) {
+	cases := []struct {
+		value, currency, want string
+	}{
+		{"100", "USD", "100.00"},
+		{"0.5", "USD", "0.50"},
+		{"1005", "JPY", "1005"},
+	}
+	for _, c := range cases {
+		a := Amount{Value: decimal.RequireFromString(c.value), Currency: c.currency}
+		data, err := json.Marshal(a)
+		if err != nil {
+			t.Fatalf("Marshal(%s %s): %v", c.value, c.currency, err)
+		}
+		want := `{"amount":"` + c.want + `","currency":"` + c.currency + `"}`
+		if string(data) != want {
+			t.Errorf("Marshal(%s %s) = %s, want %s", c.value, c.currency, data, want)
+		}
+	}
+}
+
+func TestAmount_MarshalJSON_UnknownCurrency(t *testing.T) {
+	a := Amount{Value: decimal.RequireFromString("100"), Currency: "NOPE"}
+	if _, err := json.Marshal(a); err == nil {
+		t.Fatal("expected an error for an unknown currency, got none")
+	}
+}
@@ -0,0 +1,59 @@
+package report
+
+import (
+	"encoding/csv"
+	"io"
+	"strings"
+
+	"github.com/franz-deleon/eob-check/internal/eob"
+)
+
+type csvRenderer struct{}
+
+func (csvRenderer) Render(w io.Writer, rep eob.Report) error {
+	cw := csv.NewWriter(w)
+	defer cw.Flush()
+
+	if err := cw.Write([]string{"key", "check_number", "check_total", "items_total", "check_file", "error_codes", "error_messages"}); err != nil {
+		return err
+	}
+
+	if len(rep.Errors) > 0 {
+		if err := cw.Write([]string{"", "", "", "", "", joinCodes(rep.Errors), joinMessages(rep.Errors)}); err != nil {
+			return err
+		}
+	}
+
+	for _, set := range rep.Sets {
+		row := []string{
+			set.Key,
+			set.CheckNumber,
+			set.CheckTotal.String(),
+			set.ItemsTotal.String(),
+			set.CheckFile,
+			joinCodes(set.Errors),
+			joinMessages(set.Errors),
+		}
+		if err := cw.Write(row); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func joinCodes(issues []eob.Issue) string {
+	codes := make([]string, len(issues))
+	for i, issue := range issues {
+		codes[i] = string(issue.Code)
+	}
+	return strings.Join(codes, ";")
+}
+
+func joinMessages(issues []eob.Issue) string {
+	messages := make([]string, len(issues))
+	for i, issue := range issues {
+		messages[i] = issue.Message
+	}
+	return strings.Join(messages, ";")
+}
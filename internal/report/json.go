@@ -0,0 +1,16 @@
+package report
+
+import (
+	"encoding/json"
+	"io"
+
+	"github.com/franz-deleon/eob-check/internal/eob"
+)
+
+type jsonRenderer struct{}
+
+func (jsonRenderer) Render(w io.Writer, rep eob.Report) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(rep)
+}
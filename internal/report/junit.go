@@ -0,0 +1,64 @@
+package report
+
+import (
+	"encoding/xml"
+	"io"
+
+	"github.com/franz-deleon/eob-check/internal/eob"
+)
+
+type junitRenderer struct{}
+
+type junitFailure struct {
+	Type    string `xml:"type,attr"`
+	Message string `xml:",chardata"`
+}
+
+type junitTestCase struct {
+	Name      string         `xml:"name,attr"`
+	ClassName string         `xml:"classname,attr"`
+	Failures  []junitFailure `xml:"failure"`
+}
+
+type junitTestSuite struct {
+	XMLName   xml.Name        `xml:"testsuite"`
+	Name      string          `xml:"name,attr"`
+	Tests     int             `xml:"tests,attr"`
+	Failures  int             `xml:"failures,attr"`
+	TestCases []junitTestCase `xml:"testcase"`
+}
+
+func (junitRenderer) Render(w io.Writer, rep eob.Report) error {
+	suite := junitTestSuite{Name: "eob-check"}
+
+	if len(rep.Errors) > 0 {
+		tc := junitTestCase{Name: "run", ClassName: "eob-check.run"}
+		for _, issue := range rep.Errors {
+			tc.Failures = append(tc.Failures, junitFailure{Type: string(issue.Code), Message: issue.Message})
+		}
+		suite.TestCases = append(suite.TestCases, tc)
+		suite.Failures += len(tc.Failures)
+	}
+
+	for _, set := range rep.Sets {
+		tc := junitTestCase{Name: set.Key, ClassName: "eob-check.set"}
+		for _, issue := range set.Errors {
+			tc.Failures = append(tc.Failures, junitFailure{Type: string(issue.Code), Message: issue.Message})
+		}
+		suite.TestCases = append(suite.TestCases, tc)
+		suite.Failures += len(tc.Failures)
+	}
+
+	suite.Tests = len(suite.TestCases)
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+	if err := enc.Encode(suite); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
@@ -0,0 +1,42 @@
+// Package report renders an eob.Report in the format a downstream consumer
+// needs: a human-readable numbered list, or machine-readable JSON, CSV or
+// JUnit for pipeline integration.
+package report
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/franz-deleon/eob-check/internal/eob"
+)
+
+// Format selects which Renderer Get returns.
+type Format string
+
+const (
+	Text  Format = "text"
+	JSON  Format = "json"
+	CSV   Format = "csv"
+	JUnit Format = "junit"
+)
+
+// Renderer writes an eob.Report to w in its own format.
+type Renderer interface {
+	Render(w io.Writer, rep eob.Report) error
+}
+
+// Get resolves a Format to its Renderer.
+func Get(format Format) (Renderer, error) {
+	switch format {
+	case "", Text:
+		return textRenderer{}, nil
+	case JSON:
+		return jsonRenderer{}, nil
+	case CSV:
+		return csvRenderer{}, nil
+	case JUnit:
+		return junitRenderer{}, nil
+	default:
+		return nil, fmt.Errorf("report: unknown format %q, want one of text, json, csv, junit", format)
+	}
+}
@@ -0,0 +1,126 @@
+package report
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/franz-deleon/eob-check/internal/eob"
+)
+
+func sampleReport() eob.Report {
+	return eob.Report{
+		SetTotal: decimal.RequireFromString("50.00"),
+		Expected: decimal.RequireFromString("100.00"),
+		Errors: []eob.Issue{
+			{Code: eob.EExpectedTotalMismatch, Message: "The expected total of 100 does not equal 50"},
+		},
+		Sets: []eob.SetReport{
+			{
+				Key:         "10000-1001",
+				CheckNumber: "1001",
+				CheckTotal:  decimal.RequireFromString("100.00"),
+				ItemsTotal:  decimal.RequireFromString("50.00"),
+				CheckFile:   "10000-1001_check.pdf",
+				Currency:    "USD",
+				Errors: []eob.Issue{
+					{Code: eob.EItemsTotalMismatch, Message: "Check total 100 does not match item totals 50 for 10000-1001"},
+				},
+			},
+		},
+	}
+}
+
+func TestTextRenderer(t *testing.T) {
+	var buf bytes.Buffer
+	if err := (textRenderer{}).Render(&buf, sampleReport()); err != nil {
+		t.Fatalf("Render: %v", err)
+	}
+	out := buf.String()
+	if !strings.Contains(out, "1.") || !strings.Contains(out, "2.") {
+		t.Errorf("expected two numbered lines, got %q", out)
+	}
+}
+
+func TestTextRenderer_LocaleAwareTotal(t *testing.T) {
+	var buf bytes.Buffer
+	if err := (textRenderer{}).Render(&buf, sampleReport()); err != nil {
+		t.Fatalf("Render: %v", err)
+	}
+	if !strings.Contains(buf.String(), "Total: $ 50.00") {
+		t.Errorf("expected a locale-formatted total, got %q", buf.String())
+	}
+}
+
+func TestTextRenderer_NoErrors(t *testing.T) {
+	var buf bytes.Buffer
+	if err := (textRenderer{}).Render(&buf, eob.Report{}); err != nil {
+		t.Fatalf("Render: %v", err)
+	}
+	if strings.TrimSpace(buf.String()) != "No errors found" {
+		t.Errorf("got %q, want %q", buf.String(), "No errors found")
+	}
+}
+
+func TestJSONRenderer(t *testing.T) {
+	var buf bytes.Buffer
+	if err := (jsonRenderer{}).Render(&buf, sampleReport()); err != nil {
+		t.Fatalf("Render: %v", err)
+	}
+
+	var decoded eob.Report
+	if err := json.Unmarshal(buf.Bytes(), &decoded); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if len(decoded.Sets) != 1 || decoded.Sets[0].Key != "10000-1001" {
+		t.Fatalf("unexpected decoded report: %+v", decoded)
+	}
+	if !decoded.SetTotal.Equal(sampleReport().SetTotal) || !decoded.Sets[0].CheckTotal.Equal(sampleReport().Sets[0].CheckTotal) {
+		t.Fatalf("amounts did not round-trip: %+v", decoded)
+	}
+}
+
+func TestJSONRenderer_CanonicalAmountShape(t *testing.T) {
+	var buf bytes.Buffer
+	if err := (jsonRenderer{}).Render(&buf, sampleReport()); err != nil {
+		t.Fatalf("Render: %v", err)
+	}
+	out := buf.String()
+	if !strings.Contains(out, `"CheckTotal": {`) || !strings.Contains(out, `"amount": "100.00"`) || !strings.Contains(out, `"currency": "USD"`) {
+		t.Errorf("expected amounts in the canonical {amount,currency} shape, got %s", out)
+	}
+}
+
+func TestCSVRenderer(t *testing.T) {
+	var buf bytes.Buffer
+	if err := (csvRenderer{}).Render(&buf, sampleReport()); err != nil {
+		t.Fatalf("Render: %v", err)
+	}
+	out := buf.String()
+	if !strings.Contains(out, "10000-1001") {
+		t.Errorf("expected set key in output, got %q", out)
+	}
+	if !strings.Contains(out, string(eob.EItemsTotalMismatch)) {
+		t.Errorf("expected error code in output, got %q", out)
+	}
+}
+
+func TestJUnitRenderer(t *testing.T) {
+	var buf bytes.Buffer
+	if err := (junitRenderer{}).Render(&buf, sampleReport()); err != nil {
+		t.Fatalf("Render: %v", err)
+	}
+	out := buf.String()
+	if !strings.Contains(out, "<testsuite") || !strings.Contains(out, "<failure") {
+		t.Errorf("expected a testsuite with failures, got %q", out)
+	}
+}
+
+func TestGet_UnknownFormat(t *testing.T) {
+	if _, err := Get("bogus"); err == nil {
+		t.Fatal("expected an error for an unknown format, got none")
+	}
+}
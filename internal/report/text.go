@@ -0,0 +1,61 @@
+package report
+
+import (
+	"fmt"
+	"io"
+
+	"golang.org/x/text/language"
+
+	"github.com/franz-deleon/eob-check/internal/eob"
+	"github.com/franz-deleon/eob-check/internal/money"
+)
+
+// textRenderer reproduces the original numbered-list stdout output.
+type textRenderer struct{}
+
+func (textRenderer) Render(w io.Writer, rep eob.Report) error {
+	n := 0
+
+	for _, issue := range rep.Errors {
+		n++
+		fmt.Fprintf(w, "%d. %s\n", n, issue.Message)
+	}
+	for _, set := range rep.Sets {
+		for _, issue := range set.Errors {
+			n++
+			fmt.Fprintf(w, "%d. %s\n", n, issue.Message)
+		}
+	}
+
+	if n == 0 {
+		fmt.Fprintln(w, "No errors found")
+	}
+
+	if total, ok := formatSetTotal(rep); ok {
+		fmt.Fprintf(w, "Total: %s\n", total)
+	}
+
+	return nil
+}
+
+// formatSetTotal renders rep.SetTotal locale-aware, with thousands
+// separators and a currency symbol, using the currency shared by its sets
+// (defaulting to USD). It reports false when there are no sets to derive a
+// currency from, or the currency can't be formatted.
+func formatSetTotal(rep eob.Report) (string, bool) {
+	if len(rep.Sets) == 0 {
+		return "", false
+	}
+
+	currency := rep.Sets[0].Currency
+	if currency == "" {
+		currency = "USD"
+	}
+
+	amount := money.Amount{Value: rep.SetTotal, Currency: currency}
+	formatted, err := amount.Format(language.AmericanEnglish)
+	if err != nil {
+		return "", false
+	}
+	return formatted, true
+}